@@ -0,0 +1,28 @@
+package httpx
+
+import "net/http"
+
+// MiddlewareFunc wraps a handler with cross-cutting behavior, such as auth,
+// logging or CORS headers, that should run before it.
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// Router represents a http.Handler that can register and serve routes.
+type Router interface {
+	http.Handler
+	Handle(method string, path string, handler http.Handler) error
+	SetNotFoundHandler(handler http.Handler)
+	SetNotAllowedHandler(handler http.Handler)
+	// Group returns a RouteGroup that registers routes under prefix,
+	// wrapped with mws and with any middleware added to the group before
+	// the route is registered.
+	Group(prefix string, mws ...MiddlewareFunc) RouteGroup
+}
+
+// RouteGroup registers routes under a common path prefix and middleware
+// chain. Groups nest: a child group inherits its parent's prefix and
+// middleware, and can add more of its own.
+type RouteGroup interface {
+	Group(prefix string, mws ...MiddlewareFunc) RouteGroup
+	Use(mws ...MiddlewareFunc)
+	Handle(method, subPath string, handler http.Handler) error
+}
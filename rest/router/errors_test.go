@@ -0,0 +1,67 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeromicro/go-zero/rest/router/tree"
+)
+
+func TestPanicHandlerRecoversPanickingHandler(t *testing.T) {
+	var recovered interface{}
+	pr := NewRouterWithOptions()
+	pr.(*patRouter).SetPanicHandler(func(w http.ResponseWriter, r *http.Request, rec interface{}) {
+		recovered = rec
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	assert.NoError(t, pr.Handle(http.MethodGet, "/boom", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("kaboom")
+		})))
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	resp := httptest.NewRecorder()
+
+	assert.NotPanics(t, func() {
+		pr.ServeHTTP(resp, req)
+	})
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, "kaboom", recovered)
+}
+
+func TestHandleErrorsAreTypedRouteErrors(t *testing.T) {
+	pr := NewRouterWithOptions()
+	assert.NoError(t, pr.Handle(http.MethodGet, "/dup", okHandler()))
+
+	err := pr.Handle(http.MethodGet, "/dup", okHandler())
+	var routeErr *RouteError
+	assert.True(t, errors.As(err, &routeErr))
+	assert.True(t, errors.Is(err, ErrDuplicateRoute))
+	assert.Equal(t, http.MethodGet, routeErr.Method)
+
+	err = pr.Handle("NOPE", "/foo", okHandler())
+	assert.True(t, errors.Is(err, ErrInvalidMethod))
+
+	err = pr.Handle(http.MethodGet, "nope", okHandler())
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+
+	err = pr.Handle(http.MethodGet, "/a/*x/b", okHandler())
+	assert.True(t, errors.Is(err, ErrWildcardPosition))
+
+	assert.NoError(t, pr.Handle(http.MethodGet, "/conflict/:id", okHandler()))
+	err = pr.Handle(http.MethodGet, "/conflict/:other/tail", okHandler())
+	assert.True(t, errors.Is(err, ErrConflictingParam))
+}
+
+func TestTreeErrorSentinelsAreDefined(t *testing.T) {
+	// Handle in patrouter.go maps these tree sentinels to the router's own;
+	// any other tree error is wrapped in a RouteError as is. This test only
+	// checks that the tree sentinels exist and are non-nil.
+	assert.NotNil(t, tree.ErrDuplicateItem)
+	assert.NotNil(t, tree.ErrCatchAllNotLast)
+	assert.NotNil(t, tree.ErrConflictingParam)
+}
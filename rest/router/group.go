@@ -0,0 +1,70 @@
+package router
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// routeGroup is a httpx.RouteGroup bound to a prefix and an ordered chain
+// of middleware, registering its routes against the patRouter that created it.
+type routeGroup struct {
+	pr     *patRouter
+	prefix string
+	mws    []httpx.MiddlewareFunc
+}
+
+// Group returns a RouteGroup that registers routes under prefix, wrapped
+// with mws.
+func (pr *patRouter) Group(prefix string, mws ...httpx.MiddlewareFunc) httpx.RouteGroup {
+	return &routeGroup{
+		pr:     pr,
+		prefix: cleanPrefix(prefix),
+		mws:    append([]httpx.MiddlewareFunc(nil), mws...),
+	}
+}
+
+// Group returns a nested RouteGroup under g's prefix, inheriting g's
+// middleware ahead of mws.
+func (g *routeGroup) Group(prefix string, mws ...httpx.MiddlewareFunc) httpx.RouteGroup {
+	combined := make([]httpx.MiddlewareFunc, 0, len(g.mws)+len(mws))
+	combined = append(combined, g.mws...)
+	combined = append(combined, mws...)
+
+	return &routeGroup{
+		pr:     g.pr,
+		prefix: path.Join(g.prefix, prefix),
+		mws:    combined,
+	}
+}
+
+// Use appends mws to the middleware this group applies to routes
+// registered after the call.
+func (g *routeGroup) Use(mws ...httpx.MiddlewareFunc) {
+	g.mws = append(g.mws, mws...)
+}
+
+// Handle registers handler for method at prefix+subPath, composing it with
+// the group's middleware chain so the outermost group runs first.
+func (g *routeGroup) Handle(method, subPath string, handler http.Handler) error {
+	for i := len(g.mws) - 1; i >= 0; i-- {
+		handler = g.mws[i](handler)
+	}
+
+	fullPath := path.Join(g.prefix, subPath)
+	if fullPath != "/" && len(subPath) > 0 && subPath[len(subPath)-1] == '/' {
+		// path.Join cleans away a trailing slash, but the router treats
+		// "/foo" and "/foo/" as distinct routes, so restore it.
+		fullPath += "/"
+	}
+	return g.pr.Handle(method, fullPath, handler)
+}
+
+// cleanPrefix normalizes prefix to a cleaned, slash-rooted path.
+func cleanPrefix(prefix string) string {
+	if len(prefix) == 0 || prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+	return path.Clean(prefix)
+}
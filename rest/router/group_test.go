@@ -0,0 +1,70 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func recordingMiddleware(name string, order *[]string) httpx.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestGroupMiddlewareRunsOutermostFirst(t *testing.T) {
+	var order []string
+	pr := NewRouterWithOptions()
+
+	outer := pr.Group("/api", recordingMiddleware("outer", &order))
+	inner := outer.Group("/v1", recordingMiddleware("inner", &order))
+	inner.Use(recordingMiddleware("used", &order))
+
+	assert.NoError(t, inner.Handle(http.MethodGet, "/ping", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "handler")
+			w.WriteHeader(http.StatusOK)
+		})))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []string{"outer", "inner", "used", "handler"}, order)
+}
+
+func TestGroupNotFoundBypassesMiddleware(t *testing.T) {
+	var order []string
+	pr := NewRouterWithOptions()
+	group := pr.Group("/api", recordingMiddleware("outer", &order))
+	assert.NoError(t, group.Handle(http.MethodGet, "/ping", okHandler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/missing", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Empty(t, order)
+}
+
+func TestGroupMethodNotAllowedBypassesMiddleware(t *testing.T) {
+	var order []string
+	pr := NewRouterWithOptions()
+	group := pr.Group("/api", recordingMiddleware("outer", &order))
+	assert.NoError(t, group.Handle(http.MethodGet, "/ping", okHandler()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ping", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Empty(t, order)
+}
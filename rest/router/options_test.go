@@ -0,0 +1,82 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoOptionsAggregatesAllowedMethods(t *testing.T) {
+	pr := NewRouterWithOptions(WithHandleOptions())
+	assert.NoError(t, pr.Handle(http.MethodGet, "/member/:id", okHandler()))
+	assert.NoError(t, pr.Handle(http.MethodPost, "/member/:id", okHandler()))
+
+	req := httptest.NewRequest(http.MethodOptions, "/member/123", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	allow := resp.Header().Get("Allow")
+	assert.Contains(t, allow, http.MethodGet)
+	assert.Contains(t, allow, http.MethodPost)
+	assert.Contains(t, allow, http.MethodOptions)
+}
+
+func TestAutoOptionsHonorsWildcard(t *testing.T) {
+	pr := NewRouterWithOptions(WithHandleOptions())
+	assert.NoError(t, pr.Handle(http.MethodPost, "/member/*path", okHandler()))
+
+	req := httptest.NewRequest(http.MethodOptions, "/member/anything", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	allow := resp.Header().Get("Allow")
+	assert.Contains(t, allow, http.MethodPost)
+	assert.Contains(t, allow, http.MethodOptions)
+}
+
+func TestExplicitOptionsRouteTakesPrecedence(t *testing.T) {
+	pr := NewRouterWithOptions(WithHandleOptions())
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo", okHandler()))
+	assert.NoError(t, pr.Handle(http.MethodOptions, "/foo", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})))
+
+	req := httptest.NewRequest(http.MethodOptions, "/foo", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusTeapot, resp.Code)
+}
+
+func TestGlobalOptionsHandlerRuns(t *testing.T) {
+	var ran bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ran = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	pr := NewRouterWithOptions(WithHandleOptions(), WithGlobalOptionsHandler(handler))
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo", okHandler()))
+
+	req := httptest.NewRequest(http.MethodOptions, "/foo", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.True(t, ran)
+	assert.NotEmpty(t, resp.Header().Get("Allow"))
+}
+
+func TestOptionsWithoutHandleOptionsFallsThrough(t *testing.T) {
+	pr := NewRouterWithOptions()
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo", okHandler()))
+
+	req := httptest.NewRequest(http.MethodOptions, "/foo", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
@@ -2,13 +2,14 @@ package router
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 	"strings"
 
-	"github.com/zeromicro/go-zero/core/search"
 	"github.com/zeromicro/go-zero/rest/httpx"
 	"github.com/zeromicro/go-zero/rest/pathvar"
+	"github.com/zeromicro/go-zero/rest/router/tree"
 )
 
 const (
@@ -21,130 +22,182 @@ var (
 	ErrInvalidMethod = errors.New("not a valid http method")
 	// ErrInvalidPath is an error that indicates path does not start with /.
 	ErrInvalidPath = errors.New("path must begin with '/'")
+	// ErrWildcardCount is an error that indicates a path has more than one wildcard (*).
+	ErrWildcardCount = errors.New("only one wildcard (*) is allowed in a path")
+	// ErrWildcardPosition is an error that indicates a wildcard (*) is not the last path segment.
+	ErrWildcardPosition = errors.New("wildcard (*) must be the last path segment")
+	// ErrConflictingParam is an error that indicates a :param or *wildcard segment
+	// was registered under the same path prefix with a different name.
+	ErrConflictingParam = errors.New("conflicting param name for the path")
+	// ErrDuplicateRoute is an error that indicates the method and path were already registered.
+	ErrDuplicateRoute = errors.New("duplicate route")
 )
 
+// PanicHandler is called, instead of letting the handler's panic unwind the
+// stack and crash the process, whenever a registered handler panics.
+type PanicHandler func(http.ResponseWriter, *http.Request, interface{})
+
+// RouteError carries the method and path a routing error occurred for, so
+// callers can errors.Is/errors.As it and log something actionable at
+// server bootstrap.
+type RouteError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *RouteError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.Path, e.Err)
+}
+
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
 type patRouter struct {
-	trees map[string]*search.Tree
-	// NEW: 存储通配符路由 {method: {wildcardPath: handler}}
-	wildcardRoutes map[string]map[string]http.Handler
-	notFound       http.Handler
-	notAllowed     http.Handler
+	trees                 map[string]*tree.Tree
+	notFound              http.Handler
+	notAllowed            http.Handler
+	redirectTrailingSlash bool
+	redirectFixedPath     bool
+	handleOptions         bool
+	globalOptionsHandler  http.Handler
+	panicHandler          PanicHandler
+}
+
+// RouterOption customizes a patRouter created through NewRouterWithOptions.
+type RouterOption func(*patRouter)
+
+// WithRedirectTrailingSlash makes the router redirect to the registered
+// route when a request only differs from it by a trailing slash.
+func WithRedirectTrailingSlash() RouterOption {
+	return func(pr *patRouter) {
+		pr.redirectTrailingSlash = true
+	}
+}
+
+// WithRedirectFixedPath makes the router redirect to the registered route
+// when a request matches one case-insensitively, after collapsing any
+// repeated slashes and `..` elements.
+func WithRedirectFixedPath() RouterOption {
+	return func(pr *patRouter) {
+		pr.redirectFixedPath = true
+	}
+}
+
+// WithHandleOptions makes the router auto-answer OPTIONS requests for any
+// path that has at least one registered method, unless the path also has
+// an explicit OPTIONS route.
+func WithHandleOptions() RouterOption {
+	return func(pr *patRouter) {
+		pr.handleOptions = true
+	}
+}
+
+// WithGlobalOptionsHandler sets a handler invoked for auto-answered OPTIONS
+// requests instead of the default 204 No Content, e.g. for CORS preflight.
+// The Allow header is already populated by the time it runs.
+func WithGlobalOptionsHandler(handler http.Handler) RouterOption {
+	return func(pr *patRouter) {
+		pr.globalOptionsHandler = handler
+	}
 }
 
 // NewRouter returns a httpx.Router.
 func NewRouter() httpx.Router {
-	return &patRouter{
-		trees: make(map[string]*search.Tree),
-		// NEW: 初始化通配符路由存储
-		wildcardRoutes: make(map[string]map[string]http.Handler),
+	return NewRouterWithOptions()
+}
+
+// NewRouterWithOptions returns a httpx.Router customized by opts.
+func NewRouterWithOptions(opts ...RouterOption) httpx.Router {
+	pr := &patRouter{
+		trees: make(map[string]*tree.Tree),
 	}
+	for _, opt := range opts {
+		opt(pr)
+	}
+	return pr
 }
 
 func (pr *patRouter) Handle(method, reqPath string, handler http.Handler) error {
 	if !validMethod(method) {
-		return ErrInvalidMethod
+		return &RouteError{Method: method, Path: reqPath, Err: ErrInvalidMethod}
 	}
 
 	if len(reqPath) == 0 || reqPath[0] != '/' {
-		return ErrInvalidPath
+		return &RouteError{Method: method, Path: reqPath, Err: ErrInvalidPath}
 	}
 
-	cleanPath := path.Clean(reqPath)
-
-	// NEW: 识别并存储通配符路由（含 * 的路径）
-	if strings.Contains(cleanPath, "*") {
-		// 校验通配符规则：只能有一个 *，且必须在最后一个片段
-		if strings.Count(cleanPath, "*") > 1 {
-			return errors.New("only one wildcard (*) is allowed in path")
-		}
-		parts := strings.Split(cleanPath, "/")
-		lastPart := parts[len(parts)-1]
-		if !strings.HasPrefix(lastPart, "*") {
-			return errors.New("wildcard (*) must be in the last path segment, e.g. /member/*path")
-		}
-
-		// 初始化当前方法的通配符路由表
-		if _, ok := pr.wildcardRoutes[method]; !ok {
-			pr.wildcardRoutes[method] = make(map[string]http.Handler)
-		}
-		// 存储通配符路由（去重）
-		if _, exists := pr.wildcardRoutes[method][cleanPath]; exists {
-			return duplicatedItem(cleanPath)
-		}
-		pr.wildcardRoutes[method][cleanPath] = handler
-		return nil
+	if strings.Count(reqPath, "*") > 1 {
+		return &RouteError{Method: method, Path: reqPath, Err: ErrWildcardCount}
 	}
 
-	// 原有逻辑：处理普通路由（精确/: 参数）
-	tree, ok := pr.trees[method]
-	if ok {
-		return tree.Add(cleanPath, handler)
+	cleanPath := normalizePath(reqPath)
+	t, ok := pr.trees[method]
+	if !ok {
+		t = tree.New()
+		pr.trees[method] = t
 	}
 
-	tree = search.NewTree()
-	pr.trees[method] = tree
-	return tree.Add(cleanPath, handler)
+	switch err := t.Add(cleanPath, handler); err {
+	case nil:
+		return nil
+	case tree.ErrDuplicateItem:
+		return &RouteError{Method: method, Path: reqPath, Err: ErrDuplicateRoute}
+	case tree.ErrCatchAllNotLast:
+		return &RouteError{Method: method, Path: reqPath, Err: ErrWildcardPosition}
+	case tree.ErrConflictingParam:
+		return &RouteError{Method: method, Path: reqPath, Err: ErrConflictingParam}
+	default:
+		return &RouteError{Method: method, Path: reqPath, Err: err}
+	}
 }
 
 func (pr *patRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	reqPath := path.Clean(r.URL.Path)
+	if pr.panicHandler != nil {
+		defer func() {
+			if rec := recover(); rec != nil {
+				pr.panicHandler(w, r, rec)
+			}
+		}()
+	}
+
+	reqPath := normalizePath(r.URL.Path)
 	method := r.Method
 
-	// 步骤1：先尝试原生匹配（精确/: 参数）
-	if tree, ok := pr.trees[method]; ok {
-		if result, ok := tree.Search(reqPath); ok {
+	if t, ok := pr.trees[method]; ok {
+		if result, ok := t.Search(reqPath); ok {
 			if len(result.Params) > 0 {
-				r = pathvar.WithVars(r, result.Params)
+				vars := make(map[string]string, len(result.Params))
+				for _, p := range result.Params {
+					vars[p.Key] = p.Value
+				}
+				r = pathvar.WithVars(r, vars)
 			}
+			t.Release(result.Params)
 			result.Item.(http.Handler).ServeHTTP(w, r)
 			return
 		}
-	}
 
-	// NEW: 步骤2：处理 * 通配符路由匹配
-	if wildcardMap, ok := pr.wildcardRoutes[method]; ok {
-		for wildcardPath, handler := range wildcardMap {
-			// 拆分通配符路径：/member/*path → ["", "member", "*path"]
-			parts := strings.Split(wildcardPath, "/")
-			var prefix string
-			var paramName string
-
-			// 遍历找到通配符片段，拼接前缀
-			for i, part := range parts {
-				if strings.HasPrefix(part, "*") {
-					// 拼接通配符前缀（如 /member/）
-					if i == 0 {
-						prefix = "/"
-					} else {
-						prefix = strings.Join(parts[:i], "/") + "/"
-					}
-					// 提取通配符参数名（*path → path）
-					paramName = strings.TrimPrefix(part, "*")
-					break
-				}
-			}
-
-			// 检查请求路径是否以通配符前缀开头
-			if strings.HasPrefix(reqPath, prefix) {
-				// 提取通配符参数值（如 /member/user/123 → user/123）
-				paramValue := strings.TrimPrefix(reqPath, prefix)
-				// 初始化参数并存入请求上下文
-				params := map[string]string{paramName: paramValue}
-				r = pathvar.WithVars(r, params)
-				// 执行通配符路由对应的 handler
-				handler.ServeHTTP(w, r)
-				return
-			}
+		if pr.redirectTrailingSlash && pr.tryRedirectTrailingSlash(w, r, t, reqPath) {
+			return
+		}
+		if pr.redirectFixedPath && pr.tryRedirectFixedPath(w, r, t, reqPath) {
+			return
 		}
 	}
 
-	// 步骤3：原有 405/404 逻辑
 	allows, ok := pr.methodsAllowed(method, reqPath)
 	if !ok {
 		pr.handleNotFound(w, r)
 		return
 	}
 
+	if method == http.MethodOptions && pr.handleOptions {
+		pr.serveOptions(w, r, allows)
+		return
+	}
+
 	if pr.notAllowed != nil {
 		pr.notAllowed.ServeHTTP(w, r)
 	} else {
@@ -161,6 +214,12 @@ func (pr *patRouter) SetNotAllowedHandler(handler http.Handler) {
 	pr.notAllowed = handler
 }
 
+// SetPanicHandler registers handler to recover from panics raised by route
+// handlers, so one failing request can't take down the whole process.
+func (pr *patRouter) SetPanicHandler(handler PanicHandler) {
+	pr.panicHandler = handler
+}
+
 func (pr *patRouter) handleNotFound(w http.ResponseWriter, r *http.Request) {
 	if pr.notFound != nil {
 		pr.notFound.ServeHTTP(w, r)
@@ -169,45 +228,92 @@ func (pr *patRouter) handleNotFound(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// tryRedirectTrailingSlash serves a redirect when reqPath only differs from
+// a registered route by a trailing slash.
+func (pr *patRouter) tryRedirectTrailingSlash(w http.ResponseWriter, r *http.Request, t *tree.Tree, reqPath string) bool {
+	var altered string
+	if strings.HasSuffix(reqPath, "/") {
+		altered = strings.TrimSuffix(reqPath, "/")
+		if altered == "" {
+			return false
+		}
+	} else {
+		altered = reqPath + "/"
+	}
+
+	result, ok := t.Search(altered)
+	if !ok {
+		return false
+	}
+	t.Release(result.Params)
+	redirect(w, r, altered)
+	return true
+}
+
+// tryRedirectFixedPath serves a redirect when reqPath matches a registered
+// route case-insensitively, once slashes and `..` elements are collapsed.
+func (pr *patRouter) tryRedirectFixedPath(w http.ResponseWriter, r *http.Request, t *tree.Tree, reqPath string) bool {
+	fixed, ok := t.FindCaseInsensitivePath(reqPath, pr.redirectTrailingSlash)
+	if !ok {
+		return false
+	}
+	redirect(w, r, string(fixed))
+	return true
+}
+
+// normalizePath collapses "//" and ".." elements like path.Clean, but
+// preserves a trailing slash: the tree treats "/foo" and "/foo/" as
+// distinct routes so RedirectTrailingSlash has something to redirect between.
+func normalizePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	cleaned := path.Clean(p)
+	if cleaned != "/" && p[len(p)-1] == '/' {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// redirect writes a 301 for GET/HEAD requests and a 307 for everything else,
+// preserving the method and body as required by RFC 7231.
+func redirect(w http.ResponseWriter, r *http.Request, newPath string) {
+	code := http.StatusMovedPermanently
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		code = http.StatusTemporaryRedirect
+	}
+
+	u := *r.URL
+	u.Path = newPath
+	http.Redirect(w, r, u.String(), code)
+}
+
+// serveOptions answers an auto-handled OPTIONS request with the aggregated
+// Allow header, deferring to globalOptionsHandler when one is set.
+func (pr *patRouter) serveOptions(w http.ResponseWriter, r *http.Request, allows string) {
+	w.Header().Set(allowHeader, allows+allowMethodSeparator+http.MethodOptions)
+	if pr.globalOptionsHandler != nil {
+		pr.globalOptionsHandler.ServeHTTP(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (pr *patRouter) methodsAllowed(method, path string) (string, bool) {
 	var allows []string
 
-	for treeMethod, tree := range pr.trees {
+	for treeMethod, t := range pr.trees {
 		if treeMethod == method {
 			continue
 		}
 
-		_, ok := tree.Search(path)
-		if ok {
+		if result, ok := t.Search(path); ok {
+			t.Release(result.Params)
 			allows = append(allows, treeMethod)
 		}
 	}
 
-	// NEW: 检查通配符路由的 method allowed
-	for treeMethod, wildcardMap := range pr.wildcardRoutes {
-		if treeMethod == method {
-			continue
-		}
-		for wildcardPath := range wildcardMap {
-			parts := strings.Split(wildcardPath, "/")
-			var prefix string
-			for i, part := range parts {
-				if strings.HasPrefix(part, "*") {
-					if i == 0 {
-						prefix = "/"
-					} else {
-						prefix = strings.Join(parts[:i], "/") + "/"
-					}
-					break
-				}
-			}
-			if strings.HasPrefix(path, prefix) {
-				allows = append(allows, treeMethod)
-				break
-			}
-		}
-	}
-
 	if len(allows) > 0 {
 		return strings.Join(allows, allowMethodSeparator), true
 	}
@@ -222,7 +328,3 @@ func validMethod(method string) bool {
 		method == http.MethodPut
 }
 
-// NEW: 新增重复路由错误处理函数（和 search 包对齐）
-func duplicatedItem(item string) error {
-	return errors.New("duplicated item for " + item)
-}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestRedirectTrailingSlashAdded(t *testing.T) {
+	pr := NewRouterWithOptions(WithRedirectTrailingSlash())
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo/", okHandler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, resp.Code)
+	assert.Equal(t, "/foo/", resp.Header().Get("Location"))
+}
+
+func TestRedirectTrailingSlashRemoved(t *testing.T) {
+	pr := NewRouterWithOptions(WithRedirectTrailingSlash())
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo", okHandler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/foo/", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, resp.Code)
+	assert.Equal(t, "/foo", resp.Header().Get("Location"))
+}
+
+func TestNoTrailingSlashRedirectWithoutOption(t *testing.T) {
+	pr := NewRouterWithOptions()
+	assert.NoError(t, pr.Handle(http.MethodGet, "/foo", okHandler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/foo/", nil)
+	resp := httptest.NewRecorder()
+	pr.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
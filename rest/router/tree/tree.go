@@ -0,0 +1,292 @@
+// Package tree implements a radix-style trie of URL path segments, in the
+// spirit of httprouter: each node is static, :param or *catchAll, and
+// siblings are kept in priority order so the hottest, most specific
+// branches are tried first.
+package tree
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDuplicateItem indicates the path was already registered.
+	ErrDuplicateItem = errors.New("duplicate item for the path")
+	// ErrCatchAllNotLast indicates a *catchAll segment was not the final segment.
+	ErrCatchAllNotLast = errors.New("catch-all segment must be the last path segment")
+	// ErrConflictingParam indicates a :param or *catchAll segment was registered
+	// under the same node with a different name.
+	ErrConflictingParam = errors.New("conflicting param name for the path")
+)
+
+type nodeType uint8
+
+// Node kinds are ordered so that sorting by nType alone yields the
+// static > param > catchAll priority httprouter relies on.
+const (
+	staticNode nodeType = iota
+	paramNode
+	catchAllNode
+)
+
+// Param is a single path parameter extracted on a successful Search.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params is an ordered list of path parameters. It is cheaper to build and
+// reuse than a map, and Tree hands them out from a sync.Pool.
+type Params []Param
+
+type node struct {
+	nType    nodeType
+	path     string // literal segment for staticNode, param/catchAll name otherwise
+	priority uint32
+	children []*node
+	item     any
+}
+
+// Tree matches URL paths against registered static, :param and *catchAll
+// segments, one path segment per level. A lookup is not a single pass: on a
+// dead end it backtracks and tries the next sibling branch.
+type Tree struct {
+	root *node
+	pool *sync.Pool
+}
+
+// New returns an empty Tree.
+func New() *Tree {
+	return &Tree{
+		root: new(node),
+		pool: &sync.Pool{
+			New: func() any {
+				return make(Params, 0, 4)
+			},
+		},
+	}
+}
+
+// Add registers item under path, splitting it into static/:param/*catchAll
+// segments and growing the tree as needed.
+func (t *Tree) Add(path string, item any) error {
+	n := t.root
+	segs := splitSegments(path)
+	for i, seg := range segs {
+		nt, name := segType(seg)
+		if nt == catchAllNode && i != len(segs)-1 {
+			return ErrCatchAllNotLast
+		}
+
+		child, err := n.matchChild(nt, name)
+		if err != nil {
+			return err
+		}
+		if child == nil {
+			child = &node{nType: nt, path: name}
+			n.children = append(n.children, child)
+		}
+		child.priority++
+		n.sortChildren()
+		n = child
+	}
+
+	if n.item != nil {
+		return ErrDuplicateItem
+	}
+	n.item = item
+	return nil
+}
+
+// Result is a successful Search match.
+type Result struct {
+	Item   any
+	Params Params
+}
+
+// Search walks the tree for path, returning the registered item and any
+// :param/*catchAll values collected along the way. The returned Params
+// must be passed to Release once the caller is done with it.
+func (t *Tree) Search(path string) (Result, bool) {
+	params := t.pool.Get().(Params)
+
+	n, params, ok := t.root.search(splitSegments(path), params)
+	if !ok {
+		t.Release(params)
+		return Result{}, false
+	}
+	return Result{Item: n.item, Params: params}, true
+}
+
+// search tries n's children in priority order (static, then param, then
+// catchAll), descending into each candidate and backtracking to the next
+// sibling if that branch turns out to be a dead end. This lets a static
+// route and a :param/*catchAll route coexist under the same prefix.
+func (n *node) search(segs []string, params Params) (*node, Params, bool) {
+	if len(segs) == 0 {
+		if n.item == nil {
+			return nil, params, false
+		}
+		return n, params, true
+	}
+
+	seg, rest := segs[0], segs[1:]
+	for _, c := range n.children {
+		switch c.nType {
+		case staticNode:
+			if c.path != seg {
+				continue
+			}
+			if m, p, ok := c.search(rest, params); ok {
+				return m, p, true
+			}
+		case paramNode:
+			if m, p, ok := c.search(rest, append(params, Param{Key: c.path, Value: seg})); ok {
+				return m, p, true
+			}
+		case catchAllNode:
+			if c.item == nil {
+				continue
+			}
+			return c, append(params, Param{Key: c.path, Value: strings.Join(segs, "/")}), true
+		}
+	}
+	return nil, params, false
+}
+
+// Release returns params to the pool. Callers must not use params afterwards.
+func (t *Tree) Release(params Params) {
+	t.pool.Put(params[:0])
+}
+
+// FindCaseInsensitivePath walks the tree ignoring case for static segments,
+// reconstructing the canonically-registered path on a match. When
+// fixTrailingSlash is set and the exact path isn't found, it also retries
+// with the trailing slash added or removed.
+func (t *Tree) FindCaseInsensitivePath(path string, fixTrailingSlash bool) ([]byte, bool) {
+	if fixed, ok := t.findCaseInsensitive(path); ok {
+		return fixed, true
+	}
+	if !fixTrailingSlash {
+		return nil, false
+	}
+
+	toggled := strings.TrimSuffix(path, "/")
+	if toggled == path {
+		toggled += "/"
+	}
+	return t.findCaseInsensitive(toggled)
+}
+
+func (t *Tree) findCaseInsensitive(path string) ([]byte, bool) {
+	buf := make([]byte, 0, len(path)+1)
+	n, ok := t.root.matchCaseInsensitive(splitSegments(path), &buf)
+	if !ok || n.item == nil {
+		return nil, false
+	}
+	if len(buf) == 0 {
+		buf = append(buf, '/')
+	}
+	return buf, true
+}
+
+// matchCaseInsensitive recursively matches segs against the subtree rooted
+// at n, comparing static segments with unicode.ToLower and passing :param
+// and *catchAll segments through untouched, backtracking via buf on a dead end.
+func (n *node) matchCaseInsensitive(segs []string, buf *[]byte) (*node, bool) {
+	if len(segs) == 0 {
+		return n, n.item != nil
+	}
+
+	seg, rest := segs[0], segs[1:]
+	for _, c := range n.children {
+		switch c.nType {
+		case staticNode:
+			if !strings.EqualFold(c.path, seg) {
+				continue
+			}
+			mark := len(*buf)
+			*buf = append(*buf, '/')
+			*buf = append(*buf, c.path...)
+			if m, ok := c.matchCaseInsensitive(rest, buf); ok {
+				return m, true
+			}
+			*buf = (*buf)[:mark]
+		case paramNode:
+			mark := len(*buf)
+			*buf = append(*buf, '/')
+			*buf = append(*buf, seg...)
+			if m, ok := c.matchCaseInsensitive(rest, buf); ok {
+				return m, true
+			}
+			*buf = (*buf)[:mark]
+		case catchAllNode:
+			if c.item == nil {
+				continue
+			}
+			*buf = append(*buf, '/')
+			*buf = append(*buf, strings.Join(segs, "/")...)
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// matchChild finds the existing child of n that a new registration for
+// (nt, name) should extend, if any.
+func (n *node) matchChild(nt nodeType, name string) (*node, error) {
+	for _, c := range n.children {
+		if c.nType != nt {
+			continue
+		}
+		if nt == staticNode {
+			if c.path == name {
+				return c, nil
+			}
+			continue
+		}
+		// only one :param child and one *catchAll child are allowed per node.
+		if c.path != name {
+			return nil, ErrConflictingParam
+		}
+		return c, nil
+	}
+	return nil, nil
+}
+
+// sortChildren keeps children ordered static > param > catchAll, and within
+// a kind, by descending priority, so the hottest branches are tried first.
+func (n *node) sortChildren() {
+	sort.Slice(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if a.nType != b.nType {
+			return a.nType < b.nType
+		}
+		return a.priority > b.priority
+	})
+}
+
+// splitSegments splits path into segments on "/", trimming only the
+// leading slash. A trailing slash is kept as a final empty segment so that
+// "/foo" and "/foo/" address distinct nodes, as RedirectTrailingSlash
+// requires.
+func splitSegments(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	if len(path) == 0 {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func segType(seg string) (nodeType, string) {
+	switch {
+	case strings.HasPrefix(seg, ":"):
+		return paramNode, seg[1:]
+	case strings.HasPrefix(seg, "*"):
+		return catchAllNode, seg[1:]
+	default:
+		return staticNode, seg
+	}
+}
@@ -0,0 +1,79 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeParamMatch(t *testing.T) {
+	tr := New()
+	assert.NoError(t, tr.Add("/user/:id", "user"))
+
+	result, ok := tr.Search("/user/123")
+	assert.True(t, ok)
+	assert.Equal(t, "user", result.Item)
+	assert.Equal(t, Params{{Key: "id", Value: "123"}}, result.Params)
+	tr.Release(result.Params)
+}
+
+func TestTreeCatchAllAndParamCoexist(t *testing.T) {
+	tr := New()
+	assert.NoError(t, tr.Add("/member/*path", "catchAll"))
+	assert.NoError(t, tr.Add("/member/:id/detail", "detail"))
+
+	result, ok := tr.Search("/member/abc")
+	assert.True(t, ok)
+	assert.Equal(t, "catchAll", result.Item)
+	assert.Equal(t, Params{{Key: "path", Value: "abc"}}, result.Params)
+	tr.Release(result.Params)
+
+	result, ok = tr.Search("/member/abc/detail")
+	assert.True(t, ok)
+	assert.Equal(t, "detail", result.Item)
+	assert.Equal(t, Params{{Key: "id", Value: "abc"}}, result.Params)
+	tr.Release(result.Params)
+}
+
+func TestTreeBacktracksPastDeadEndStatic(t *testing.T) {
+	tr := New()
+	assert.NoError(t, tr.Add("/a/b/c", "static"))
+	assert.NoError(t, tr.Add("/a/:x/d", "param"))
+
+	result, ok := tr.Search("/a/b/c")
+	assert.True(t, ok)
+	assert.Equal(t, "static", result.Item)
+	tr.Release(result.Params)
+
+	// "/a/b/d" dead-ends under the static "b" branch (no "d" child), so the
+	// tree must backtrack and try the sibling ":x" branch instead.
+	result, ok = tr.Search("/a/b/d")
+	assert.True(t, ok)
+	assert.Equal(t, "param", result.Item)
+	assert.Equal(t, Params{{Key: "x", Value: "b"}}, result.Params)
+	tr.Release(result.Params)
+}
+
+func TestTreeTrailingSlashIsDistinct(t *testing.T) {
+	tr := New()
+	assert.NoError(t, tr.Add("/foo", "no-slash"))
+	assert.NoError(t, tr.Add("/foo/", "slash"))
+
+	result, ok := tr.Search("/foo")
+	assert.True(t, ok)
+	assert.Equal(t, "no-slash", result.Item)
+	tr.Release(result.Params)
+
+	result, ok = tr.Search("/foo/")
+	assert.True(t, ok)
+	assert.Equal(t, "slash", result.Item)
+	tr.Release(result.Params)
+}
+
+func TestTreeSearchMiss(t *testing.T) {
+	tr := New()
+	assert.NoError(t, tr.Add("/user/:id", "user"))
+
+	_, ok := tr.Search("/other")
+	assert.False(t, ok)
+}